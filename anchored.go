@@ -0,0 +1,25 @@
+package diff
+
+import (
+	"context"
+
+	"github.com/pkg/diff/myers"
+)
+
+// Anchored returns the differences between a and b computed by Myers, but
+// with lines named in anchors forced to align when they occur exactly once
+// on each side. It is useful for diffing generated code, config files, or
+// transcripts where certain marker lines (a "func main", a section
+// heading, a test name) are known to correspond, and should not be allowed
+// to drift apart just because Myers finds a different, equally short, edit
+// script. Anchored degrades to plain Myers if no anchors match uniquely on
+// both sides.
+func Anchored(ctx context.Context, ab DiffWrite, anchors []string) myers.EditScript {
+	return myers.AnchoredStrings(ctx, ab, anchors)
+}
+
+// AnchoredFunc is like Anchored, but accepts a predicate instead of a
+// literal list of anchor lines.
+func AnchoredFunc(ctx context.Context, ab DiffWrite, isAnchor func(line string) bool) myers.EditScript {
+	return myers.Anchored(ctx, ab, isAnchor)
+}
@@ -0,0 +1,171 @@
+package diff
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/diff/write"
+)
+
+// AssertOpt configures AssertEqual.
+type AssertOpt func(*assertConfig)
+
+type assertConfig struct {
+	nameA, nameB string
+	contextSize  int
+	equal        func(x, y interface{}) bool
+}
+
+// AssertNames sets the labels AssertEqual uses for want and got in its
+// output. The default is "want" and "got".
+//
+// Named AssertNames, not Names, because diff.Names already exists as the
+// write-option constructor used with WriteUnified.
+func AssertNames(a, b string) AssertOpt {
+	return func(c *assertConfig) { c.nameA, c.nameB = a, b }
+}
+
+// ContextSize sets how many unchanged lines of context AssertEqual prints
+// around each difference. The default is 3.
+func ContextSize(n int) AssertOpt {
+	return func(c *assertConfig) { c.contextSize = n }
+}
+
+// EqualFunc overrides the equality test AssertEqual uses when got and want
+// are slices of a type Slices wouldn't know how to compare with
+// reflect.DeepEqual.
+func EqualFunc(equal func(x, y interface{}) bool) AssertOpt {
+	return func(c *assertConfig) { c.equal = equal }
+}
+
+// AssertEqual compares got against want and, if they differ, calls
+// t.Errorf with a unified diff labeled "want"/"got" (or the names set via
+// AssertNames). It dispatches on the runtime type of got and want: []string and
+// [][]byte diff line-by-line, []byte is split into lines first, and any
+// other slice type falls back to Slices. Non-slice arguments are
+// pretty-printed one field per line and the resulting line arrays are
+// diffed, so struct-vs-struct comparisons still produce a readable
+// field-level diff.
+func AssertEqual(t testing.TB, got, want interface{}, opts ...AssertOpt) {
+	t.Helper()
+
+	cfg := assertConfig{nameA: "want", nameB: "got", contextSize: 3}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ab, equal := assertPair(got, want, cfg)
+	if equal {
+		return
+	}
+
+	var buf bytes.Buffer
+	e := Myers(context.Background(), ab)
+	wopts := []write.WriteOpt{write.Names(cfg.nameA, cfg.nameB)}
+	// testing.Verbose() panics if called outside a running test, which is
+	// safe here only because AssertEqual's testing.TB parameter means one
+	// always is.
+	if testing.Verbose() && isTerminal(os.Stdout) {
+		wopts = append(wopts, write.TerminalColor())
+	}
+	e.WithContextSize(cfg.contextSize).WriteUnified(&buf, ab, wopts...)
+	t.Errorf("%s != %s:\n%s", cfg.nameA, cfg.nameB, buf.String())
+}
+
+// assertPair returns a DiffWrite for got and want, and whether they were
+// already equal (in which case the DiffWrite is unused).
+func assertPair(got, want interface{}, cfg assertConfig) (DiffWrite, bool) {
+	switch w := want.(type) {
+	case []string:
+		g, ok := got.([]string)
+		if !ok {
+			return mismatchedTypes(got, want), false
+		}
+		return Strings(w, g), reflect.DeepEqual(w, g)
+	case []byte:
+		g, ok := got.([]byte)
+		if !ok {
+			return mismatchedTypes(got, want), false
+		}
+		wl, gl := bytes.Split(w, []byte("\n")), bytes.Split(g, []byte("\n"))
+		return Bytes(wl, gl), bytes.Equal(w, g)
+	case [][]byte:
+		g, ok := got.([][]byte)
+		if !ok {
+			return mismatchedTypes(got, want), false
+		}
+		return Bytes(w, g), reflect.DeepEqual(w, g)
+	}
+
+	rw := reflect.ValueOf(want)
+	if rw.IsValid() && rw.Kind() == reflect.Slice {
+		equal := cfg.equal
+		if equal == nil {
+			equal = reflect.DeepEqual
+		}
+		ab := Slices(want, got, equal)
+		if cfg.equal == nil {
+			return ab, reflect.DeepEqual(want, got)
+		}
+		same := ab.LenA() == ab.LenB()
+		for i := 0; same && i < ab.LenA(); i++ {
+			same = ab.Equal(i, i)
+		}
+		return ab, same
+	}
+
+	equal := cfg.equal
+	if equal == nil {
+		equal = reflect.DeepEqual
+	}
+	wl, gl := prettyLines(want), prettyLines(got)
+	return Strings(wl, gl), equal(want, got)
+}
+
+// mismatchedTypes handles got and want having different concrete types: it
+// falls back to pretty-printing both, which is always defined even when
+// the slice dispatch above can't apply.
+func mismatchedTypes(got, want interface{}) DiffWrite {
+	return Strings(prettyLines(want), prettyLines(got))
+}
+
+// prettyLines renders v as a list of lines: one "Field: value" line per
+// exported struct field for structs (and the struct pointed to, for
+// pointers), or a single line via fmt.Sprintf("%#v", ...) otherwise.
+func prettyLines(v interface{}) []string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return strings.Split(fmt.Sprintf("%#v", v), "\n")
+	}
+	rt := rv.Type()
+	lines := make([]string, 0, rt.NumField()+2)
+	lines = append(lines, rt.Name()+"{")
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("\t%s: %#v,", f.Name, rv.Field(i).Interface()))
+	}
+	lines = append(lines, "}")
+	return lines
+}
+
+// isTerminal reports whether w looks like it's connected to a terminal, so
+// AssertEqual only asks for colorized output when it would actually show
+// up as color rather than raw escape codes.
+func isTerminal(w *os.File) bool {
+	fi, err := w.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,91 @@
+package diff
+
+import "io"
+
+// StringIntern maps distinct strings to a single canonical *string, so that
+// repeated equal strings share one allocation. Diffing large inputs spends
+// most of its time inside Equal doing byte-wise string comparison; once
+// every line involved has been interned, Equal reduces to comparing two
+// pointers, which is why StringsInterned and BytesInterned take a
+// *StringIntern instead of comparing []string or [][]byte directly.
+//
+// Interning pays an O(n) cost up front (one map lookup per line, for every
+// line in both A and B) in exchange for turning each of the O(n·m)
+// comparisons Myers may perform into a pointer compare instead of a
+// byte-wise one. For large inputs with many repeated lines - the common
+// case for diffing two versions of a log file - the up-front cost is
+// recovered many times over.
+//
+// The zero value is not usable; construct one with NewStringIntern.
+type StringIntern struct {
+	s map[string]*string
+}
+
+// NewStringIntern returns an empty StringIntern.
+func NewStringIntern() *StringIntern {
+	return &StringIntern{s: make(map[string]*string)}
+}
+
+// String returns the canonical *string for s, interning it if this is the
+// first time s has been seen.
+func (in *StringIntern) String(s string) *string {
+	if p, ok := in.s[s]; ok {
+		return p
+	}
+	p := new(string)
+	*p = s
+	in.s[s] = p
+	return p
+}
+
+// Bytes is like String, but interns the string representation of b.
+func (in *StringIntern) Bytes(b []byte) *string {
+	return in.String(string(b))
+}
+
+// StringsInterned is like Strings, but interns every line of a and b
+// through intern up front so that Equal compares pointers rather than
+// bytes. Reuse the same *StringIntern across multiple diffs of related
+// inputs (e.g. successive versions of the same file) to amortize the
+// interning cost further.
+func StringsInterned(intern *StringIntern, a, b []string) DiffWrite {
+	return &diffInterned{a: internAll(intern, a), b: internAll(intern, b)}
+}
+
+// BytesInterned is the [][]byte equivalent of StringsInterned.
+func BytesInterned(intern *StringIntern, a, b [][]byte) DiffWrite {
+	return &diffInterned{a: internAllBytes(intern, a), b: internAllBytes(intern, b)}
+}
+
+func internAll(intern *StringIntern, a []string) []*string {
+	out := make([]*string, len(a))
+	for i, s := range a {
+		out[i] = intern.String(s)
+	}
+	return out
+}
+
+func internAllBytes(intern *StringIntern, a [][]byte) []*string {
+	out := make([]*string, len(a))
+	for i, b := range a {
+		out[i] = intern.Bytes(b)
+	}
+	return out
+}
+
+// diffInterned is a DiffWrite over already-interned lines: Equal is a
+// pointer comparison, and WriteATo/WriteBTo dereference the canonical
+// string.
+type diffInterned struct {
+	a, b []*string
+}
+
+func (ab *diffInterned) LenA() int             { return len(ab.a) }
+func (ab *diffInterned) LenB() int             { return len(ab.b) }
+func (ab *diffInterned) Equal(ai, bi int) bool { return ab.a[ai] == ab.b[bi] }
+func (ab *diffInterned) WriteATo(w io.Writer, i int) (int, error) {
+	return io.WriteString(w, *ab.a[i])
+}
+func (ab *diffInterned) WriteBTo(w io.Writer, i int) (int, error) {
+	return io.WriteString(w, *ab.b[i])
+}
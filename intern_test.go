@@ -0,0 +1,52 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// logCorpus builds two versions of a synthetic log file: n lines drawn
+// from a small set of repeated message templates, with a handful of lines
+// changed between a and b. This is the shape StringIntern targets - lots
+// of repeated lines, few actual differences - and is representative of
+// diffing two versions of the same long-running log.
+func logCorpus(n int) (a, b []string) {
+	templates := []string{
+		"INFO  request completed in 12ms",
+		"DEBUG cache hit for key user:1234",
+		"WARN  retrying connection to backend",
+		"INFO  request completed in 9ms",
+		"DEBUG cache miss for key user:5678",
+	}
+	a = make([]string, n)
+	b = make([]string, n)
+	for i := range a {
+		line := templates[i%len(templates)]
+		a[i] = line
+		b[i] = line
+	}
+	for i := 0; i < n; i += 97 {
+		b[i] = fmt.Sprintf("ERROR unexpected EOF at offset %d", i)
+	}
+	return a, b
+}
+
+func BenchmarkMyersPlain(b *testing.B) {
+	a, c := logCorpus(5000)
+	ab := Strings(a, c)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Myers(context.Background(), ab)
+	}
+}
+
+func BenchmarkMyersInterned(b *testing.B) {
+	a, c := logCorpus(5000)
+	intern := NewStringIntern()
+	ab := StringsInterned(intern, a, c)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Myers(context.Background(), ab)
+	}
+}
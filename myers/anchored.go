@@ -0,0 +1,75 @@
+package myers
+
+import "context"
+
+// Anchored computes an EditScript for ab, forcing alignment on any line
+// that satisfies isAnchor and occurs exactly once on each side. Those lines
+// become fixed match points and the diff is computed independently on each
+// inter-anchor sub-slice, falling back to ordinary Diff where no anchors
+// apply. This is useful for diffing generated code, config files, or
+// transcripts where certain marker lines (a function signature, a section
+// heading) are known to line up, and Myers' choice of matches elsewhere
+// shouldn't be allowed to drift across them.
+//
+// If no anchors match uniquely on both sides, Anchored degrades to plain
+// Diff.
+func Anchored(ctx context.Context, ab TextPair, isAnchor func(line string) bool) EditScript {
+	at, bt := textsOf(ab)
+	anchors := anchorMatches(at, bt, isAnchor)
+	if len(anchors) == 0 {
+		return Diff(ctx, ab)
+	}
+
+	var es EditScript
+	pa, pb := 0, 0
+	for _, m := range anchors {
+		es = append(es, myersRange(ctx, ab, pa, m.a, pb, m.b)...)
+		es = append(es, Segment{Op: Equal, LowA: m.a, HighA: m.a + 1, LowB: m.b, HighB: m.b + 1})
+		pa, pb = m.a+1, m.b+1
+	}
+	es = append(es, myersRange(ctx, ab, pa, ab.LenA(), pb, ab.LenB())...)
+	return es
+}
+
+// AnchoredStrings is a convenience for the common case of anchoring on a
+// literal set of lines rather than a predicate.
+func AnchoredStrings(ctx context.Context, ab TextPair, anchors []string) EditScript {
+	set := make(map[string]bool, len(anchors))
+	for _, a := range anchors {
+		set[a] = true
+	}
+	return Anchored(ctx, ab, func(line string) bool { return set[line] })
+}
+
+// anchorMatches returns, in increasing order of a, the lines accepted by
+// isAnchor that occur exactly once in at and exactly once in bt and are
+// equal between the two. As in uniqueCommonMatches, the candidates are
+// reduced to the longest subsequence whose b positions are increasing, so
+// an anchor that would cross the others is dropped.
+func anchorMatches(at, bt []string, isAnchor func(string) bool) []match {
+	countA := make(map[string]int, len(at))
+	for _, s := range at {
+		countA[s]++
+	}
+	countB := make(map[string]int, len(bt))
+	for _, s := range bt {
+		countB[s]++
+	}
+	posB := make(map[string]int, len(bt))
+	for i, s := range bt {
+		if countB[s] == 1 {
+			posB[s] = i
+		}
+	}
+
+	var candidates []match
+	for i, s := range at {
+		if countA[s] != 1 || !isAnchor(s) {
+			continue
+		}
+		if bi, ok := posB[s]; ok {
+			candidates = append(candidates, match{i, bi})
+		}
+	}
+	return longestIncreasingB(candidates)
+}
@@ -0,0 +1,42 @@
+package myers
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAnchoredForcesMatchOnMarkerLine(t *testing.T) {
+	// "func main() {" occurs exactly once on each side and we anchor on it
+	// explicitly; Anchored must line up a's occurrence with b's as a
+	// one-line Equal segment rather than leaving the choice to Myers.
+	a := []string{"package main", "func main() {", "\tprintln(1)", "}"}
+	b := []string{"package main", "func main() {", "\tprintln(2)", "}"}
+	es := Anchored(context.Background(), &linesPair{a, b}, func(line string) bool {
+		return line == "func main() {"
+	})
+	reconstruct(t, es, a, b)
+
+	found := false
+	for _, seg := range es {
+		if seg.Op == Equal && seg.HighA-seg.LowA == 1 && a[seg.LowA] == "func main() {" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("no Equal segment anchored on %q in %v", "func main() {", es)
+	}
+}
+
+func TestAnchoredDegradesWithoutUniqueAnchor(t *testing.T) {
+	a := []string{"x", "y", "z"}
+	b := []string{"x", "y", "z"}
+	es := Anchored(context.Background(), &linesPair{a, b}, func(line string) bool { return line == "nope" })
+	reconstruct(t, es, a, b)
+}
+
+func TestAnchoredStrings(t *testing.T) {
+	a := []string{"one", "two", "three"}
+	b := []string{"two", "one", "three"}
+	es := AnchoredStrings(context.Background(), &linesPair{a, b}, []string{"three"})
+	reconstruct(t, es, a, b)
+}
@@ -0,0 +1,195 @@
+package myers
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/diff/write"
+)
+
+// jsonHunk mirrors one hunk of a unified diff, but as data rather than
+// text: oldStart/oldLines and newStart/newLines are 1-based, matching the
+// @@ -oldStart,oldLines +newStart,newLines @@ header they would otherwise
+// produce, and Segments carries the hunk body as operation+lines groups
+// instead of prefixed text.
+type jsonHunk struct {
+	OldStart int           `json:"oldStart"`
+	OldLines int           `json:"oldLines"`
+	NewStart int           `json:"newStart"`
+	NewLines int           `json:"newLines"`
+	Segments []jsonSegment `json:"segments"`
+
+	lastA, lastB int // HighA/HighB of the last segment appended, for hunk splitting
+}
+
+type jsonSegment struct {
+	Op    string   `json:"op"`
+	Lines []string `json:"lines"`
+}
+
+func (op Op) jsonString() string {
+	switch op {
+	case Delete:
+		return "delete"
+	case Insert:
+		return "insert"
+	default:
+		return "equal"
+	}
+}
+
+// WriteJSON writes e as a JSON object describing the diff between ab's A
+// and B sides, rather than as unified diff text. Downstream consumers -
+// review UIs, CI annotators, structured loggers - can then render the
+// diff however they like without re-parsing a unified patch. The same
+// WithContextSize trimming that WriteUnified honors applies here too: a
+// run of Segments that WithContextSize separated by cutting out unchanged
+// lines starts a new hunk. The Names option is consumed into the output's
+// nameA/nameB fields; TerminalColor has no effect, since JSON output
+// carries no escape codes.
+func (e EditScript) WriteJSON(w io.Writer, ab write.Pair, opts ...write.WriteOpt) (int, error) {
+	var cfg write.Config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	doc := jsonDoc{NameA: cfg.NameA, NameB: cfg.NameB, Hunks: e.jsonHunks(ab)}
+	buf, err := json.Marshal(doc)
+	if err != nil {
+		return 0, err
+	}
+	return w.Write(buf)
+}
+
+// jsonHunks groups e into hunks, starting a new one whenever a segment
+// doesn't pick up exactly where the previous one left off - which is what
+// WithContextSize trimming a long unchanged run out of the middle of e
+// looks like.
+func (e EditScript) jsonHunks(ab write.Pair) []jsonHunk {
+	var hunks []jsonHunk
+	var cur *jsonHunk
+	for _, seg := range e {
+		if cur != nil && (seg.LowA != cur.lastA || seg.LowB != cur.lastB) {
+			hunks = append(hunks, *cur)
+			cur = nil
+		}
+		if cur == nil {
+			cur = &jsonHunk{OldStart: seg.LowA + 1, NewStart: seg.LowB + 1}
+		}
+		lines := readLines(ab, seg)
+		cur.Segments = append(cur.Segments, jsonSegment{Op: seg.Op.jsonString(), Lines: lines})
+		cur.OldLines += seg.HighA - seg.LowA
+		cur.NewLines += seg.HighB - seg.LowB
+		cur.lastA, cur.lastB = seg.HighA, seg.HighB
+	}
+	if cur != nil {
+		hunks = append(hunks, *cur)
+	}
+	return hunks
+}
+
+func readLines(ab write.Pair, seg Segment) []string {
+	switch seg.Op {
+	case Delete:
+		lines := make([]string, 0, seg.HighA-seg.LowA)
+		for i := seg.LowA; i < seg.HighA; i++ {
+			lines = append(lines, readLine(ab.WriteATo, i))
+		}
+		return lines
+	case Insert:
+		lines := make([]string, 0, seg.HighB-seg.LowB)
+		for i := seg.LowB; i < seg.HighB; i++ {
+			lines = append(lines, readLine(ab.WriteBTo, i))
+		}
+		return lines
+	default: // Equal
+		lines := make([]string, 0, seg.HighA-seg.LowA)
+		for i := seg.LowA; i < seg.HighA; i++ {
+			lines = append(lines, readLine(ab.WriteATo, i))
+		}
+		return lines
+	}
+}
+
+func readLine(writeTo func(io.Writer, int) (int, error), i int) string {
+	var buf []byte
+	w := byteWriter{&buf}
+	writeTo(w, i)
+	return string(buf)
+}
+
+type byteWriter struct{ buf *[]byte }
+
+func (w byteWriter) Write(p []byte) (int, error) {
+	*w.buf = append(*w.buf, p...)
+	return len(p), nil
+}
+
+// jsonDoc is the wire format WriteJSON produces and ReadJSON expects: the
+// names WriteJSON's Names option set, plus the hunks themselves.
+type jsonDoc struct {
+	NameA string     `json:"nameA,omitempty"`
+	NameB string     `json:"nameB,omitempty"`
+	Hunks []jsonHunk `json:"hunks"`
+}
+
+// ReadJSON parses the output of WriteJSON, returning the EditScript and a
+// write.Pair over the A/B lines the hunks reference, suitable for passing
+// back into WriteUnified or WriteJSON.
+//
+// A multi-hunk diff - the normal case once WithContextSize trims an
+// unchanged run out of the middle of the script - has gaps between hunks
+// that WriteJSON never recorded any lines for. ReadJSON preserves each
+// hunk's original oldStart/newStart by padding lines.A/lines.B with empty
+// placeholder lines up to that offset, rather than renumbering every hunk
+// as if it picked up where the last one left off; the placeholders stand
+// in for the untracked gap the same way a unified diff leaves one between
+// hunks, so round-tripping is only lossless for a single contiguous hunk.
+func ReadJSON(r io.Reader) (EditScript, write.Pair, error) {
+	var doc jsonDoc
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, nil, err
+	}
+
+	var es EditScript
+	lines := &TextLines{}
+	for _, h := range doc.Hunks {
+		for len(lines.A) < h.OldStart-1 {
+			lines.A = append(lines.A, "")
+		}
+		for len(lines.B) < h.NewStart-1 {
+			lines.B = append(lines.B, "")
+		}
+		for _, seg := range h.Segments {
+			op := Equal
+			switch seg.Op {
+			case "delete":
+				op = Delete
+			case "insert":
+				op = Insert
+			}
+			ai, bi := len(lines.A), len(lines.B)
+			switch op {
+			case Delete:
+				lines.A = append(lines.A, seg.Lines...)
+				es = append(es, Segment{Op: Delete, LowA: ai, HighA: ai + len(seg.Lines), LowB: bi, HighB: bi})
+			case Insert:
+				lines.B = append(lines.B, seg.Lines...)
+				es = append(es, Segment{Op: Insert, LowA: ai, HighA: ai, LowB: bi, HighB: bi + len(seg.Lines)})
+			default:
+				lines.A = append(lines.A, seg.Lines...)
+				lines.B = append(lines.B, seg.Lines...)
+				es = append(es, Segment{Op: Equal, LowA: ai, HighA: ai + len(seg.Lines), LowB: bi, HighB: bi + len(seg.Lines)})
+			}
+		}
+	}
+	return es, lines, nil
+}
+
+// TextLines is a minimal write.Pair over two in-memory line slices, used to
+// reconstruct a Pair from ReadJSON.
+type TextLines struct {
+	A, B []string
+}
+
+func (t *TextLines) WriteATo(w io.Writer, i int) (int, error) { return io.WriteString(w, t.A[i]) }
+func (t *TextLines) WriteBTo(w io.Writer, i int) (int, error) { return io.WriteString(w, t.B[i]) }
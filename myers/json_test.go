@@ -0,0 +1,80 @@
+package myers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pkg/diff/write"
+)
+
+func TestJSONRoundtripMultiHunk(t *testing.T) {
+	// Two widely separated single-line replaces, with no Equal segments
+	// between them - the shape WithContextSize leaves behind once it trims
+	// the unchanged run out of the middle of a long diff. The second hunk's
+	// oldStart/newStart are well past line 1, which is what the original
+	// ReadJSON got wrong: it renumbered every hunk as if it picked up where
+	// the last one left off.
+	a := []string{"a0", "a1", "a2", "a3", "a4", "a5", "a6", "a7"}
+	b := []string{"_", "_", "X2", "X7"}
+	ab := &TextLines{A: a, B: b}
+	e := EditScript{
+		{Op: Delete, LowA: 2, HighA: 3, LowB: 2, HighB: 2},
+		{Op: Insert, LowA: 3, HighA: 3, LowB: 2, HighB: 3},
+		{Op: Delete, LowA: 7, HighA: 8, LowB: 3, HighB: 3},
+		{Op: Insert, LowA: 8, HighA: 8, LowB: 3, HighB: 4},
+	}
+
+	var buf bytes.Buffer
+	if _, err := e.WriteJSON(&buf, ab); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	got, pair, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatalf("ReadJSON: %v", err)
+	}
+	lines, ok := pair.(*TextLines)
+	if !ok {
+		t.Fatalf("ReadJSON returned %T, want *TextLines", pair)
+	}
+
+	if len(got) != len(e) {
+		t.Fatalf("got %d segments, want %d: %v", len(got), len(e), got)
+	}
+
+	// The second hunk's Delete segment must still be anchored at A index 7,
+	// not renumbered down to wherever the first hunk's lines happened to
+	// end.
+	second := got[2]
+	if second.Op != Delete || second.LowA != 7 || second.HighA != 8 {
+		t.Fatalf("second hunk's delete segment = %+v, want LowA:7 HighA:8", second)
+	}
+	if got := lines.A[second.LowA]; got != "a7" {
+		t.Errorf("lines.A[7] = %q, want %q", got, "a7")
+	}
+
+	first := got[0]
+	if first.Op != Delete || first.LowA != 2 || first.HighA != 3 {
+		t.Fatalf("first hunk's delete segment = %+v, want LowA:2 HighA:3", first)
+	}
+	if got := lines.A[first.LowA]; got != "a2" {
+		t.Errorf("lines.A[2] = %q, want %q", got, "a2")
+	}
+}
+
+func TestJSONWritesNames(t *testing.T) {
+	a := []string{"same"}
+	ab := &TextLines{A: a, B: a}
+	e := EditScript{{Op: Equal, LowA: 0, HighA: 1, LowB: 0, HighB: 1}}
+
+	var buf bytes.Buffer
+	if _, err := e.WriteJSON(&buf, ab, write.Names("old.txt", "new.txt")); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"nameA":"old.txt"`)) {
+		t.Errorf("output missing nameA: %s", buf.String())
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"nameB":"new.txt"`)) {
+		t.Errorf("output missing nameB: %s", buf.String())
+	}
+}
@@ -0,0 +1,194 @@
+package myers
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+// TextPair is a Pair whose elements can also be rendered as text. Patience
+// needs the actual line content (not just an opaque equality test) to tell
+// which lines are unique, so it requires more than the plain Pair that Diff
+// uses.
+type TextPair interface {
+	Pair
+	WriteATo(w io.Writer, i int) (int, error)
+	WriteBTo(w io.Writer, i int) (int, error)
+}
+
+// Patience computes an EditScript for ab using the patience diff algorithm.
+//
+// Patience diff first finds the lines that occur exactly once on each side
+// of ab (the "unique" lines), takes the longest increasing subsequence of
+// those matches as a set of fixed anchors, and recurses on the slices
+// between consecutive anchors. Regions where no unique lines remain fall
+// back to Diff, the ordinary Myers algorithm. The result tends to produce
+// more readable hunks than Myers alone for source code, since common
+// structural lines such as braces and blank lines are rarely unique and so
+// no longer act as false anchors.
+func Patience(ctx context.Context, ab TextPair) EditScript {
+	at, bt := textsOf(ab)
+	return patience(ctx, ab, at, bt, 0, ab.LenA(), 0, ab.LenB())
+}
+
+// textsOf renders every element of ab to a string, once, so the rest of
+// the algorithm can work with cheap string comparisons and map lookups
+// instead of re-invoking WriteATo/WriteBTo.
+func textsOf(ab TextPair) (at, bt []string) {
+	at = make([]string, ab.LenA())
+	var buf bytes.Buffer
+	for i := range at {
+		buf.Reset()
+		ab.WriteATo(&buf, i)
+		at[i] = buf.String()
+	}
+	bt = make([]string, ab.LenB())
+	for i := range bt {
+		buf.Reset()
+		ab.WriteBTo(&buf, i)
+		bt[i] = buf.String()
+	}
+	return at, bt
+}
+
+// patience diffs the half-open ranges A[loA:hiA] and B[loB:hiB] of ab,
+// given the already-rendered text of every line in at and bt.
+func patience(ctx context.Context, ab Pair, at, bt []string, loA, hiA, loB, hiB int) EditScript {
+	// Trim the common prefix and suffix - patience only needs to work on
+	// the interior that might actually differ - but still record the
+	// trimmed lines as Equal segments; they're real lines in both A and B
+	// and WriteUnified's line numbering depends on every line being
+	// accounted for somewhere in the EditScript.
+	var es EditScript
+	for loA < hiA && loB < hiB && ab.Equal(loA, loB) {
+		es = append(es, Segment{Op: Equal, LowA: loA, HighA: loA + 1, LowB: loB, HighB: loB + 1})
+		loA++
+		loB++
+	}
+	var suffix EditScript
+	for loA < hiA && loB < hiB && ab.Equal(hiA-1, hiB-1) {
+		suffix = append(suffix, Segment{Op: Equal, LowA: hiA - 1, HighA: hiA, LowB: hiB - 1, HighB: hiB})
+		hiA--
+		hiB--
+	}
+
+	anchors := uniqueCommonMatches(at, bt, loA, hiA, loB, hiB)
+	if len(anchors) == 0 {
+		es = append(es, myersRange(ctx, ab, loA, hiA, loB, hiB)...)
+	} else {
+		pa, pb := loA, loB
+		for _, m := range anchors {
+			es = append(es, patience(ctx, ab, at, bt, pa, m.a, pb, m.b)...)
+			es = append(es, Segment{Op: Equal, LowA: m.a, HighA: m.a + 1, LowB: m.b, HighB: m.b + 1})
+			pa, pb = m.a+1, m.b+1
+		}
+		es = append(es, patience(ctx, ab, at, bt, pa, hiA, pb, hiB)...)
+	}
+
+	// suffix was built last-line-first (hiA-1, hiA-2, ...); reverse it back
+	// into document order before appending.
+	for i := len(suffix) - 1; i >= 0; i-- {
+		es = append(es, suffix[i])
+	}
+	return es
+}
+
+// myersRange runs Diff restricted to the given sub-ranges by wrapping ab in
+// a Pair that offsets into those ranges, then shifts the resulting indices
+// back into the coordinates of the outer ab.
+func myersRange(ctx context.Context, ab Pair, loA, hiA, loB, hiB int) EditScript {
+	sub := Diff(ctx, &subPair{ab, loA, hiA, loB, hiB})
+	for i := range sub {
+		sub[i].LowA += loA
+		sub[i].HighA += loA
+		sub[i].LowB += loB
+		sub[i].HighB += loB
+	}
+	return sub
+}
+
+type subPair struct {
+	Pair
+	loA, hiA, loB, hiB int
+}
+
+func (s *subPair) LenA() int             { return s.hiA - s.loA }
+func (s *subPair) LenB() int             { return s.hiB - s.loB }
+func (s *subPair) Equal(ai, bi int) bool { return s.Pair.Equal(s.loA+ai, s.loB+bi) }
+
+// match is a single anchor: a line that is unique within A[loA:hiA] and
+// within B[loB:hiB], and identical between the two.
+type match struct{ a, b int }
+
+// uniqueCommonMatches returns the anchor matches for A[loA:hiA] and
+// B[loB:hiB], in increasing order of a. Candidates are restricted to lines
+// that occur exactly once on each side, then reduced to the longest
+// increasing subsequence of b-positions (patience sort), which is the
+// largest set of matches that can all hold simultaneously without crossing.
+func uniqueCommonMatches(at, bt []string, loA, hiA, loB, hiB int) []match {
+	countA := make(map[string]int, hiA-loA)
+	for i := loA; i < hiA; i++ {
+		countA[at[i]]++
+	}
+	countB := make(map[string]int, hiB-loB)
+	for i := loB; i < hiB; i++ {
+		countB[bt[i]]++
+	}
+
+	firstB := make(map[string]int, hiB-loB)
+	for i := loB; i < hiB; i++ {
+		if countB[bt[i]] == 1 {
+			firstB[bt[i]] = i
+		}
+	}
+
+	var candidates []match
+	for i := loA; i < hiA; i++ {
+		if countA[at[i]] != 1 {
+			continue
+		}
+		if bi, ok := firstB[at[i]]; ok {
+			candidates = append(candidates, match{i, bi})
+		}
+	}
+	return longestIncreasingB(candidates)
+}
+
+// longestIncreasingB returns the longest subsequence of candidates (already
+// sorted by a) whose b values are strictly increasing, using the standard
+// patience-sort / LIS construction in O(n log n).
+func longestIncreasingB(candidates []match) []match {
+	if len(candidates) == 0 {
+		return nil
+	}
+	piles := make([]int, 0, len(candidates)) // indices into candidates, pile tops
+	prev := make([]int, len(candidates))
+	for i, c := range candidates {
+		lo, hi := 0, len(piles)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if candidates[piles[mid]].b < c.b {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		if lo > 0 {
+			prev[i] = piles[lo-1]
+		} else {
+			prev[i] = -1
+		}
+		if lo == len(piles) {
+			piles = append(piles, i)
+		} else {
+			piles[lo] = i
+		}
+	}
+	seq := make([]match, len(piles))
+	i := piles[len(piles)-1]
+	for k := len(piles) - 1; k >= 0; k-- {
+		seq[k] = candidates[i]
+		i = prev[i]
+	}
+	return seq
+}
@@ -0,0 +1,92 @@
+package myers
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// linesPair is a minimal TextPair over two []string, shared by the tests in
+// this package.
+type linesPair struct{ a, b []string }
+
+func (p *linesPair) LenA() int             { return len(p.a) }
+func (p *linesPair) LenB() int             { return len(p.b) }
+func (p *linesPair) Equal(ai, bi int) bool { return p.a[ai] == p.b[bi] }
+func (p *linesPair) WriteATo(w io.Writer, i int) (int, error) {
+	return io.WriteString(w, p.a[i])
+}
+func (p *linesPair) WriteBTo(w io.Writer, i int) (int, error) {
+	return io.WriteString(w, p.b[i])
+}
+
+// reconstruct walks es and rebuilds the A lines it consumes and the B lines
+// it produces, and fails t if either doesn't exactly reproduce a/b. This
+// would have caught the prefix/suffix trim dropping Equal segments: any
+// line trimmed out without being recorded disappears from the
+// reconstruction instead of round-tripping.
+func reconstruct(t *testing.T, es EditScript, a, b []string) {
+	t.Helper()
+	var gotA, gotB []string
+	for _, seg := range es {
+		if seg.Op != Insert {
+			gotA = append(gotA, a[seg.LowA:seg.HighA]...)
+		}
+		if seg.Op != Delete {
+			gotB = append(gotB, b[seg.LowB:seg.HighB]...)
+		}
+	}
+	if !reflect.DeepEqual(gotA, a) {
+		t.Errorf("reconstructed A = %v, want %v", gotA, a)
+	}
+	if !reflect.DeepEqual(gotB, b) {
+		t.Errorf("reconstructed B = %v, want %v", gotB, b)
+	}
+}
+
+func TestPatiencePrefixSuffix(t *testing.T) {
+	a := []string{"a", "b", "c"}
+	b := []string{"a", "x", "c"}
+	es := Patience(context.Background(), &linesPair{a, b})
+	reconstruct(t, es, a, b)
+
+	var ops []Op
+	for _, seg := range es {
+		ops = append(ops, seg.Op)
+	}
+	want := []Op{Equal, Delete, Insert, Equal}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("ops = %v, want %v", ops, want)
+	}
+}
+
+func TestPatienceAnchor(t *testing.T) {
+	// "unique" occurs exactly once on each side and should anchor the
+	// recursion; the repeated "pad" lines on either side of it are not
+	// unique and must not themselves be treated as anchors.
+	a := []string{"pad", "pad", "unique", "pad", "pad"}
+	b := []string{"pad", "unique", "pad"}
+	es := Patience(context.Background(), &linesPair{a, b})
+	reconstruct(t, es, a, b)
+
+	var anchor *Segment
+	for i, seg := range es {
+		if seg.Op == Equal && seg.HighA-seg.LowA == 1 && a[seg.LowA] == "unique" {
+			anchor = &es[i]
+		}
+	}
+	if anchor == nil {
+		t.Fatalf("no Equal segment anchored on the unique line in %v", es)
+	}
+}
+
+func TestPatienceNoUniqueLines(t *testing.T) {
+	// Every line is "x", so no unique matches exist anywhere and whatever
+	// is left after trimming the common prefix must fall back to plain
+	// Myers via myersRange.
+	a := []string{"x", "x", "x"}
+	b := []string{"x", "x"}
+	es := Patience(context.Background(), &linesPair{a, b})
+	reconstruct(t, es, a, b)
+}
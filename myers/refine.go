@@ -0,0 +1,319 @@
+package myers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/diff/write"
+)
+
+// Tokenizer splits a line into the units RefineWords diffs at.
+type Tokenizer func(line string) []string
+
+// DefaultTokenizer splits line on whitespace/punctuation boundaries,
+// keeping the whitespace and punctuation runs themselves as tokens so that
+// re-joining the tokens reproduces line exactly.
+func DefaultTokenizer(line string) []string {
+	var tokens []string
+	var cur strings.Builder
+	class := func(r rune) int {
+		switch {
+		case unicode.IsSpace(r):
+			return 0
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 1
+		default:
+			return 2
+		}
+	}
+	var curClass int = -1
+	for _, r := range line {
+		c := class(r)
+		if c != curClass && cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+		cur.WriteRune(r)
+		curClass = c
+	}
+	if cur.Len() > 0 {
+		tokens = append(tokens, cur.String())
+	}
+	return tokens
+}
+
+// RuneTokenizer splits line into individual runes, for callers that want
+// character-level rather than word-level refinement.
+func RuneTokenizer(line string) []string {
+	tokens := make([]string, 0, len(line))
+	for _, r := range line {
+		tokens = append(tokens, string(r))
+	}
+	return tokens
+}
+
+// Mark wraps a token that changed, for embedding in a refined line. Old is
+// called for tokens present only in the old line, New for tokens present
+// only in the new line.
+type Mark struct {
+	Old func(token string) string
+	New func(token string) string
+}
+
+// BracketMarks renders changes the way git diff --word-diff does:
+// {-removed-} and {+added+}.
+var BracketMarks = Mark{
+	Old: func(s string) string { return "{-" + s + "-}" },
+	New: func(s string) string { return "{+" + s + "+}" },
+}
+
+// ANSIMarks renders changes with ANSI SGR codes: red for removed text,
+// green for added text. Use this when the output destination is a
+// terminal (e.g. when the caller also set write.TerminalColor()).
+var ANSIMarks = Mark{
+	Old: func(s string) string { return "\x1b[31m" + s + "\x1b[0m" },
+	New: func(s string) string { return "\x1b[32m" + s + "\x1b[0m" },
+}
+
+// RefinedLine is one line of RefineWords' output: Op is Delete, Insert, or
+// Equal exactly as in the source EditScript, and Text is that line's
+// content with Mark applied around the tokens that changed, for Delete and
+// Insert lines that were part of a refined pair.
+type RefinedLine struct {
+	Op   Op
+	Text string
+}
+
+// RefineWords re-examines each run of Delete segments immediately followed
+// by a run of Insert segments, or vice versa, in e - what git diff
+// --word-diff calls a "replace" - tokenizes the old and new lines with tok,
+// and runs a second Myers pass over the tokens to find which words actually
+// changed. Lines are paired up old[i] with new[i]; any leftover lines (the
+// old and new runs needn't be the same length) are reported unrefined, as
+// whole Delete or Insert lines with no Mark applied. Lines outside a replace pair (plain inserts,
+// deletes, or unchanged context) pass through with their original Op and
+// text.
+//
+// RefineWords only returns the marked-up lines; use WriteUnifiedWords to
+// write them out in unified diff form.
+func (e EditScript) RefineWords(ctx context.Context, ab TextPair, tok Tokenizer, mark Mark) []RefinedLine {
+	at, bt := textsOf(ab)
+
+	var out []RefinedLine
+	i := 0
+	for i < len(e) {
+		seg := e[i]
+		if seg.Op != Delete && seg.Op != Insert {
+			out = append(out, segmentLines(seg, at, bt)...)
+			i++
+			continue
+		}
+		// A replace can show up as a Delete run followed by an Insert run
+		// or the other way around; collect whichever comes first, then the
+		// opposite run that follows it.
+		firstOp := seg.Op
+		first := []Segment{seg}
+		j := i + 1
+		for j < len(e) && e[j].Op == firstOp {
+			first = append(first, e[j])
+			j++
+		}
+		secondOp := Insert
+		if firstOp == Insert {
+			secondOp = Delete
+		}
+		var second []Segment
+		for j < len(e) && e[j].Op == secondOp {
+			second = append(second, e[j])
+			j++
+		}
+		var dels, ins []Segment
+		if firstOp == Delete {
+			dels, ins = first, second
+		} else {
+			ins, dels = first, second
+		}
+		oldLines := linesOf(dels, at)
+		newLines := linesOf(ins, bt)
+		out = append(out, refinePair(ctx, oldLines, newLines, tok, mark)...)
+		i = j
+	}
+	return out
+}
+
+// WriteUnifiedWords is WriteUnified with intra-line word-diff markers
+// inlined into each changed line, using RefineWords: wherever WriteUnified
+// would print a run of whole "-old"/"+new" lines for a replace, this prints
+// the same lines with the words that actually changed wrapped in mark. It
+// produces the same "--- a\n+++ b\n@@ ... @@" hunk structure WriteUnified
+// does - RefineWords by itself only returns the marked-up lines, with no
+// headers or hunk boundaries, so using it to fill in a unified diff means
+// splitting e into hunks the same way WriteUnified does. Pass
+// write.TerminalColor() to also get ANSIMarks-style coloring instead of
+// BracketMarks, matching how WriteUnified treats the same option.
+func (e EditScript) WriteUnifiedWords(ctx context.Context, w io.Writer, ab TextPair, tok Tokenizer, opts ...write.WriteOpt) (int, error) {
+	var cfg write.Config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	mark := BracketMarks
+	if cfg.Color {
+		mark = ANSIMarks
+	}
+
+	var n int
+	writeString := func(s string) error {
+		m, err := io.WriteString(w, s)
+		n += m
+		return err
+	}
+
+	if cfg.NameA != "" || cfg.NameB != "" {
+		if err := writeString(fmt.Sprintf("--- %s\n+++ %s\n", cfg.NameA, cfg.NameB)); err != nil {
+			return n, err
+		}
+	}
+
+	for _, h := range unifiedHunks(e) {
+		header := fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", h.oldStart, h.oldLines, h.newStart, h.newLines)
+		if err := writeString(header); err != nil {
+			return n, err
+		}
+		for _, l := range h.segs.RefineWords(ctx, ab, tok, mark) {
+			var prefix string
+			switch l.Op {
+			case Delete:
+				prefix = "-"
+			case Insert:
+				prefix = "+"
+			default:
+				prefix = " "
+			}
+			if err := writeString(prefix + l.Text + "\n"); err != nil {
+				return n, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// unifiedHunk is one @@ ... @@ hunk of e: the header fields plus the
+// sub-script of e that falls inside it.
+type unifiedHunk struct {
+	oldStart, oldLines int
+	newStart, newLines int
+	segs               EditScript
+}
+
+// unifiedHunks groups e into hunks, starting a new one whenever a segment
+// doesn't pick up exactly where the previous one left off - which is what
+// WithContextSize trimming a long unchanged run out of the middle of e
+// looks like. This mirrors jsonHunks in json.go, which groups the same way
+// for WriteJSON.
+func unifiedHunks(e EditScript) []unifiedHunk {
+	var hunks []unifiedHunk
+	var cur *unifiedHunk
+	var lastA, lastB int
+	for _, seg := range e {
+		if cur != nil && (seg.LowA != lastA || seg.LowB != lastB) {
+			hunks = append(hunks, *cur)
+			cur = nil
+		}
+		if cur == nil {
+			cur = &unifiedHunk{oldStart: seg.LowA + 1, newStart: seg.LowB + 1}
+		}
+		cur.segs = append(cur.segs, seg)
+		cur.oldLines += seg.HighA - seg.LowA
+		cur.newLines += seg.HighB - seg.LowB
+		lastA, lastB = seg.HighA, seg.HighB
+	}
+	if cur != nil {
+		hunks = append(hunks, *cur)
+	}
+	return hunks
+}
+
+func segmentLines(seg Segment, at, bt []string) []RefinedLine {
+	var lines []RefinedLine
+	switch seg.Op {
+	case Insert:
+		for k := seg.LowB; k < seg.HighB; k++ {
+			lines = append(lines, RefinedLine{Op: Insert, Text: bt[k]})
+		}
+	default: // Equal
+		for k := seg.LowA; k < seg.HighA; k++ {
+			lines = append(lines, RefinedLine{Op: Equal, Text: at[k]})
+		}
+	}
+	return lines
+}
+
+func linesOf(segs []Segment, text []string) []string {
+	var lines []string
+	for _, seg := range segs {
+		for k := seg.LowA; k < seg.HighA; k++ {
+			lines = append(lines, text[k])
+		}
+		for k := seg.LowB; k < seg.HighB; k++ {
+			lines = append(lines, text[k])
+		}
+	}
+	return lines
+}
+
+// refinePair word-diffs oldLines[i] against newLines[i] for as many lines
+// as both sides have, and reports any extras unrefined.
+func refinePair(ctx context.Context, oldLines, newLines []string, tok Tokenizer, mark Mark) []RefinedLine {
+	var out []RefinedLine
+	n := len(oldLines)
+	if len(newLines) < n {
+		n = len(newLines)
+	}
+	for k := 0; k < n; k++ {
+		o, nw := refineLine(ctx, oldLines[k], newLines[k], tok, mark)
+		out = append(out, RefinedLine{Op: Delete, Text: o}, RefinedLine{Op: Insert, Text: nw})
+	}
+	for k := n; k < len(oldLines); k++ {
+		out = append(out, RefinedLine{Op: Delete, Text: oldLines[k]})
+	}
+	for k := n; k < len(newLines); k++ {
+		out = append(out, RefinedLine{Op: Insert, Text: newLines[k]})
+	}
+	return out
+}
+
+// refineLine runs tok over old and new, diffs the resulting tokens, and
+// rebuilds both lines with mark applied around the tokens that changed.
+func refineLine(ctx context.Context, old, new string, tok Tokenizer, mark Mark) (oldMarked, newMarked string) {
+	ot, nt := tok(old), tok(new)
+	es := Diff(ctx, &tokenPair{ot, nt})
+
+	var ob, nb strings.Builder
+	for _, seg := range es {
+		switch seg.Op {
+		case Equal:
+			for k := seg.LowA; k < seg.HighA; k++ {
+				ob.WriteString(ot[k])
+				nb.WriteString(ot[k])
+			}
+		case Delete:
+			for k := seg.LowA; k < seg.HighA; k++ {
+				ob.WriteString(mark.Old(ot[k]))
+			}
+		case Insert:
+			for k := seg.LowB; k < seg.HighB; k++ {
+				nb.WriteString(mark.New(nt[k]))
+			}
+		}
+	}
+	return ob.String(), nb.String()
+}
+
+type tokenPair struct{ a, b []string }
+
+func (p *tokenPair) LenA() int             { return len(p.a) }
+func (p *tokenPair) LenB() int             { return len(p.b) }
+func (p *tokenPair) Equal(ai, bi int) bool { return p.a[ai] == p.b[bi] }
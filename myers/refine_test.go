@@ -0,0 +1,85 @@
+package myers
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRefineWordsDeleteThenInsert(t *testing.T) {
+	a := []string{"the quick brown fox"}
+	b := []string{"the quick red fox"}
+	e := EditScript{
+		{Op: Delete, LowA: 0, HighA: 1, LowB: 0, HighB: 0},
+		{Op: Insert, LowA: 1, HighA: 1, LowB: 0, HighB: 1},
+	}
+	lines := e.RefineWords(context.Background(), &linesPair{a, b}, DefaultTokenizer, BracketMarks)
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[0].Text, "{-brown-}") {
+		t.Errorf("old line = %q, want a {-brown-} marker", lines[0].Text)
+	}
+	if !strings.Contains(lines[1].Text, "{+red+}") {
+		t.Errorf("new line = %q, want a {+red+} marker", lines[1].Text)
+	}
+}
+
+func TestRefineWordsInsertThenDelete(t *testing.T) {
+	// The same replace, but with the Insert segment coming first in the
+	// script - RefineWords must refine this ordering too, not just
+	// Delete-then-Insert.
+	a := []string{"the quick brown fox"}
+	b := []string{"the quick red fox"}
+	e := EditScript{
+		{Op: Insert, LowA: 0, HighA: 0, LowB: 0, HighB: 1},
+		{Op: Delete, LowA: 0, HighA: 1, LowB: 1, HighB: 1},
+	}
+	lines := e.RefineWords(context.Background(), &linesPair{a, b}, DefaultTokenizer, BracketMarks)
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %v", len(lines), lines)
+	}
+
+	var old, new string
+	for _, l := range lines {
+		switch l.Op {
+		case Delete:
+			old = l.Text
+		case Insert:
+			new = l.Text
+		}
+	}
+	if !strings.Contains(old, "{-brown-}") {
+		t.Errorf("old line = %q, want a {-brown-} marker", old)
+	}
+	if !strings.Contains(new, "{+red+}") {
+		t.Errorf("new line = %q, want a {+red+} marker", new)
+	}
+}
+
+func TestWriteUnifiedWordsEmitsHunkHeaders(t *testing.T) {
+	a := []string{"a0", "old", "a2", "a3", "a4", "a5", "a6"}
+	b := []string{"a0", "new", "a2", "a3", "a4", "a5", "a6x"}
+	// No Equal segment links the two replaces - the shape WithContextSize
+	// leaves once it trims the unchanged run between them - so they must
+	// land in two separate hunks.
+	e := EditScript{
+		{Op: Equal, LowA: 0, HighA: 1, LowB: 0, HighB: 1},
+		{Op: Delete, LowA: 1, HighA: 2, LowB: 1, HighB: 1},
+		{Op: Insert, LowA: 2, HighA: 2, LowB: 1, HighB: 2},
+		{Op: Delete, LowA: 6, HighA: 7, LowB: 6, HighB: 6},
+		{Op: Insert, LowA: 7, HighA: 7, LowB: 6, HighB: 7},
+	}
+
+	var buf strings.Builder
+	if _, err := e.WriteUnifiedWords(context.Background(), &buf, &linesPair{a, b}, DefaultTokenizer); err != nil {
+		t.Fatalf("WriteUnifiedWords: %v", err)
+	}
+	out := buf.String()
+	if strings.Count(out, "@@") != 4 {
+		t.Errorf("expected 2 hunk headers (4 '@@' markers), got:\n%s", out)
+	}
+	if !strings.Contains(out, "{-old-}") || !strings.Contains(out, "{+new+}") {
+		t.Errorf("expected word-diff markers in output:\n%s", out)
+	}
+}
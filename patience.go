@@ -0,0 +1,17 @@
+package diff
+
+import (
+	"context"
+
+	"github.com/pkg/diff/myers"
+)
+
+// Patience returns the differences between a and b computed using the
+// patience diff algorithm, an alternative to Myers that tends to produce
+// more readable hunks for source code: it anchors on lines that are unique
+// on both sides before falling back to Myers for the regions in between.
+// The result slots into the same EditScript/Segment model as Myers, so
+// e.WithContextSize(n).WriteUnified(w, ab, opts...) works unchanged.
+func Patience(ctx context.Context, ab DiffWrite) myers.EditScript {
+	return myers.Patience(ctx, ab)
+}
@@ -0,0 +1,50 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// SlicesOf returns a DiffWrite that diffs a and b using equal to compare
+// elements and format to render them. Unlike Slices, the element type is
+// monomorphic: there's no reflect.Value indexing or boxing to interface{}
+// in the Equal hot path, which matters because Equal is called once per
+// cell considered by Myers.
+//
+// As with Slices, a nil equal falls back to reflect.DeepEqual and a nil
+// format falls back to fmt.Sprint; both fall outside the fast,
+// reflect-free path this function exists for, so callers that want the
+// allocation-free Equal should pass their own. SlicesComparable
+// supplies both for comparable T.
+func SlicesOf[T any](a, b []T, equal func(x, y T) bool, format func(T) string) DiffWrite {
+	if equal == nil {
+		equal = func(x, y T) bool { return reflect.DeepEqual(x, y) }
+	}
+	if format == nil {
+		format = func(x T) string { return fmt.Sprint(x) }
+	}
+	return &diffSlicesOf[T]{a: a, b: b, eq: equal, format: format}
+}
+
+// SlicesComparable is SlicesOf for comparable element types, using == for
+// equal and fmt.Sprint for format.
+func SlicesComparable[T comparable](a, b []T) DiffWrite {
+	return SlicesOf(a, b, func(x, y T) bool { return x == y }, func(x T) string { return fmt.Sprint(x) })
+}
+
+type diffSlicesOf[T any] struct {
+	a, b   []T
+	eq     func(x, y T) bool
+	format func(T) string
+}
+
+func (ab *diffSlicesOf[T]) LenA() int             { return len(ab.a) }
+func (ab *diffSlicesOf[T]) LenB() int             { return len(ab.b) }
+func (ab *diffSlicesOf[T]) Equal(ai, bi int) bool { return ab.eq(ab.a[ai], ab.b[bi]) }
+func (ab *diffSlicesOf[T]) WriteATo(w io.Writer, i int) (int, error) {
+	return io.WriteString(w, ab.format(ab.a[i]))
+}
+func (ab *diffSlicesOf[T]) WriteBTo(w io.Writer, i int) (int, error) {
+	return io.WriteString(w, ab.format(ab.b[i]))
+}
@@ -0,0 +1,132 @@
+// Package textedit converts an EditScript into a list of insert/replace/delete
+// operations against the original document text, for callers - editors,
+// language servers, code-mod tools - that want to apply a diff rather than
+// print one.
+package textedit
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/pkg/diff/myers"
+	"github.com/pkg/diff/write"
+)
+
+// Span is a half-open byte range [Start, End) into the original document.
+// StartLine/StartCol and EndLine/EndCol are 1-based and are filled in
+// alongside the byte offsets; callers that only need byte offsets can
+// ignore them. Because FromEditScript works a line at a time, every Span it
+// produces starts and ends at column 1 - the column fields exist for
+// callers building their own sub-line spans on top of a Span's lines.
+type Span struct {
+	Start, End          int
+	StartLine, StartCol int
+	EndLine, EndCol     int
+}
+
+// TextEdit replaces the text in Span with NewText. A zero-width Span (Start
+// == End) is a pure insertion; a TextEdit with an empty NewText is a pure
+// deletion.
+type TextEdit struct {
+	Span    Span
+	NewText string
+}
+
+// FromEditScript converts e into a list of TextEdits against the A side of
+// ab, in document order. A Delete segment immediately followed by an Insert
+// segment (or vice versa) is reported as a single replace TextEdit, since
+// that is what most editors and code-mod tools expect.
+func FromEditScript(e myers.EditScript, ab write.Pair) []TextEdit {
+	lineA := func(i int) string {
+		var buf bytes.Buffer
+		ab.WriteATo(&buf, i)
+		return buf.String()
+	}
+	lineB := func(i int) string {
+		var buf bytes.Buffer
+		ab.WriteBTo(&buf, i)
+		return buf.String()
+	}
+
+	var edits []TextEdit
+	var pending *TextEdit
+	offset, line, col := 0, 1, 1
+
+	flush := func() {
+		if pending != nil {
+			edits = append(edits, *pending)
+			pending = nil
+		}
+	}
+	advance := func(n int) {
+		offset += n
+		line++
+		col = 1
+	}
+
+	for _, seg := range e {
+		switch seg.Op {
+		case myers.Equal:
+			flush()
+			for i := seg.LowA; i < seg.HighA; i++ {
+				advance(len(lineA(i)) + 1)
+			}
+		case myers.Delete:
+			start, startLine, startCol := offset, line, col
+			var n int
+			for i := seg.LowA; i < seg.HighA; i++ {
+				l := len(lineA(i)) + 1
+				n += l
+				advance(l)
+			}
+			if pending == nil {
+				pending = &TextEdit{Span: Span{Start: start, End: start + n, StartLine: startLine, StartCol: startCol, EndLine: line, EndCol: col}}
+			} else {
+				pending.Span.End = start + n
+				pending.Span.EndLine, pending.Span.EndCol = line, col
+			}
+		case myers.Insert:
+			var sb strings.Builder
+			for i := seg.LowB; i < seg.HighB; i++ {
+				sb.WriteString(lineB(i))
+				sb.WriteByte('\n')
+			}
+			if pending == nil {
+				pending = &TextEdit{Span: Span{Start: offset, End: offset, StartLine: line, StartCol: col, EndLine: line, EndCol: col}, NewText: sb.String()}
+			} else {
+				pending.NewText += sb.String()
+			}
+		}
+	}
+	flush()
+	return edits
+}
+
+// Apply applies edits, which must be sorted by Span and non-overlapping, to
+// original and returns the resulting text.
+func Apply(original string, edits []TextEdit) string {
+	var sb strings.Builder
+	pos := 0
+	for _, e := range edits {
+		sb.WriteString(original[pos:e.Span.Start])
+		sb.WriteString(e.NewText)
+		pos = e.Span.End
+	}
+	sb.WriteString(original[pos:])
+	return sb.String()
+}
+
+// ApplyToLines applies edits directly to lines, the []string passed to
+// diff.Strings, rather than to the joined document text.
+func ApplyToLines(lines []string, edits []TextEdit) []string {
+	original := strings.Join(lines, "\n")
+	if len(lines) > 0 {
+		original += "\n"
+	}
+	result := Apply(original, edits)
+	result = strings.TrimSuffix(result, "\n")
+	if result == "" {
+		return nil
+	}
+	return strings.Split(result, "\n")
+}
@@ -0,0 +1,81 @@
+package textedit
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/diff/myers"
+)
+
+type linesPair struct{ a, b []string }
+
+func (p *linesPair) WriteATo(w io.Writer, i int) (int, error) { return io.WriteString(w, p.a[i]) }
+func (p *linesPair) WriteBTo(w io.Writer, i int) (int, error) { return io.WriteString(w, p.b[i]) }
+
+func TestFromEditScriptApplyRoundtrip(t *testing.T) {
+	a := []string{"one", "two", "three", "four"}
+	b := []string{"one", "TWO", "three", "four", "five"}
+	e := myers.EditScript{
+		{Op: myers.Equal, LowA: 0, HighA: 1, LowB: 0, HighB: 1},
+		{Op: myers.Delete, LowA: 1, HighA: 2, LowB: 1, HighB: 1},
+		{Op: myers.Insert, LowA: 2, HighA: 2, LowB: 1, HighB: 2},
+		{Op: myers.Equal, LowA: 2, HighA: 4, LowB: 2, HighB: 4},
+		{Op: myers.Insert, LowA: 4, HighA: 4, LowB: 4, HighB: 5},
+	}
+
+	edits := FromEditScript(e, &linesPair{a, b})
+	got := ApplyToLines(a, edits)
+	if !reflect.DeepEqual(got, b) {
+		t.Fatalf("ApplyToLines = %v, want %v", got, b)
+	}
+}
+
+func TestFromEditScriptInsertAtEOF(t *testing.T) {
+	a := []string{"only"}
+	b := []string{"only", "appended"}
+	e := myers.EditScript{
+		{Op: myers.Equal, LowA: 0, HighA: 1, LowB: 0, HighB: 1},
+		{Op: myers.Insert, LowA: 1, HighA: 1, LowB: 1, HighB: 2},
+	}
+
+	edits := FromEditScript(e, &linesPair{a, b})
+	if len(edits) != 1 {
+		t.Fatalf("got %d edits, want 1: %v", len(edits), edits)
+	}
+	insert := edits[0]
+	if insert.Span.Start != insert.Span.End {
+		t.Errorf("insert span not zero-width: %+v", insert.Span)
+	}
+	if insert.Span.StartCol != 1 || insert.Span.EndCol != 1 {
+		t.Errorf("insert span cols = %d,%d, want 1,1", insert.Span.StartCol, insert.Span.EndCol)
+	}
+
+	got := ApplyToLines(a, edits)
+	if !reflect.DeepEqual(got, b) {
+		t.Fatalf("ApplyToLines = %v, want %v", got, b)
+	}
+}
+
+func TestFromEditScriptLineAndColTracking(t *testing.T) {
+	a := []string{"a", "b", "c"}
+	b := []string{"a", "B", "c"}
+	e := myers.EditScript{
+		{Op: myers.Equal, LowA: 0, HighA: 1, LowB: 0, HighB: 1},
+		{Op: myers.Delete, LowA: 1, HighA: 2, LowB: 1, HighB: 1},
+		{Op: myers.Insert, LowA: 2, HighA: 2, LowB: 1, HighB: 2},
+		{Op: myers.Equal, LowA: 2, HighA: 3, LowB: 2, HighB: 3},
+	}
+
+	edits := FromEditScript(e, &linesPair{a, b})
+	if len(edits) != 1 {
+		t.Fatalf("got %d edits, want 1: %v", len(edits), edits)
+	}
+	span := edits[0].Span
+	if span.StartLine != 2 || span.StartCol != 1 {
+		t.Errorf("start = line %d col %d, want line 2 col 1", span.StartLine, span.StartCol)
+	}
+	if span.EndLine != 3 || span.EndCol != 1 {
+		t.Errorf("end = line %d col %d, want line 3 col 1", span.EndLine, span.EndCol)
+	}
+}